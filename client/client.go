@@ -0,0 +1,147 @@
+/*
+ⒸAngelaMos | 2025
+client.go
+*/
+
+// Package client is a small hand-written Go client for the chronometer HTTP
+// API described by openapi.yaml. It is not code-generated; keep it in sync
+// with the server and the spec by hand when either changes.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TimerResponse mirrors the server's TimerResponse JSON shape.
+type TimerResponse struct {
+	Name             string `json:"name"`
+	IsRunning        bool   `json:"is_running"`
+	ElapsedSeconds   int64  `json:"elapsed_seconds"`
+	ElapsedFormatted string `json:"elapsed_formatted"`
+	Mode             string `json:"mode"`
+	RemainingSeconds int64  `json:"remaining_seconds,omitempty"`
+	Finished         bool   `json:"finished,omitempty"`
+}
+
+// StartRequest mirrors the server's StartRequest JSON shape.
+type StartRequest struct {
+	OffsetHours   float64 `json:"offset_hours,omitempty"`
+	TargetSeconds int64   `json:"target_seconds,omitempty"`
+	TargetAt      *string `json:"target_at,omitempty"`
+	WebhookURL    string  `json:"webhook_url,omitempty"`
+}
+
+// StatusResponse mirrors the server's generic {"status": "..."} responses.
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+// Lap mirrors the server's Lap JSON shape.
+type Lap struct {
+	ID      int    `json:"id"`
+	At      int64  `json:"at"`
+	Elapsed int64  `json:"elapsed_seconds"`
+	Delta   int64  `json:"delta_seconds"`
+	Label   string `json:"label"`
+}
+
+// Client is a thin typed wrapper over the chronometer HTTP API so other Go
+// programs can drive it without handcrafting requests.
+type Client struct {
+	Server     string
+	HTTPClient *http.Client
+}
+
+// NewClient builds a Client for the chronometer instance at server, e.g.
+// "http://localhost:8329".
+func NewClient(server string) *Client {
+	return &Client{Server: server, HTTPClient: http.DefaultClient}
+}
+
+func (c *Client) do(method, path string, body any, out any) error {
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, c.Server+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// ListTimers calls GET /api/timers.
+func (c *Client) ListTimers() ([]TimerResponse, error) {
+	var out []TimerResponse
+	err := c.do(http.MethodGet, "/api/timers", nil, &out)
+	return out, err
+}
+
+// GetTimer calls GET /api/timers/{name}.
+func (c *Client) GetTimer(name string) (*TimerResponse, error) {
+	var out TimerResponse
+	err := c.do(http.MethodGet, "/api/timers/"+url.PathEscape(name), nil, &out)
+	return &out, err
+}
+
+// StartTimer calls POST /api/timers/{name}/start.
+func (c *Client) StartTimer(name string, req StartRequest) (*StatusResponse, error) {
+	var out StatusResponse
+	err := c.do(http.MethodPost, "/api/timers/"+url.PathEscape(name)+"/start", req, &out)
+	return &out, err
+}
+
+// StopTimer calls POST /api/timers/{name}/stop.
+func (c *Client) StopTimer(name string) (*StatusResponse, error) {
+	var out StatusResponse
+	err := c.do(http.MethodPost, "/api/timers/"+url.PathEscape(name)+"/stop", nil, &out)
+	return &out, err
+}
+
+// ResetTimer calls POST /api/timers/{name}/reset.
+func (c *Client) ResetTimer(name string) (*StatusResponse, error) {
+	var out StatusResponse
+	err := c.do(http.MethodPost, "/api/timers/"+url.PathEscape(name)+"/reset", nil, &out)
+	return &out, err
+}
+
+// Lap calls POST /api/timers/{name}/lap.
+func (c *Client) Lap(name, label string) (*Lap, error) {
+	var out Lap
+	err := c.do(http.MethodPost, "/api/timers/"+url.PathEscape(name)+"/lap", map[string]string{"label": label}, &out)
+	return &out, err
+}
+
+// ListLaps calls GET /api/timers/{name}/laps.
+func (c *Client) ListLaps(name string) ([]Lap, error) {
+	var out []Lap
+	err := c.do(http.MethodGet, "/api/timers/"+url.PathEscape(name)+"/laps", nil, &out)
+	return out, err
+}
@@ -0,0 +1,205 @@
+/*
+ⒸAngelaMos | 2025
+contract_test.go
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	legacyrouter "github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gorilla/websocket"
+)
+
+// loadSpecRouter parses openapi.yaml and builds a kin-openapi router from
+// it. Servers are cleared so FindRoute matches on path alone instead of
+// trying to match the httptest server's random host against the spec's
+// fixed "http://localhost:8329" server entry.
+func loadSpecRouter(t *testing.T) routers.Router {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromFile(filepath.Join(wd, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("load openapi.yaml: %v", err)
+	}
+	if err := doc.Validate(openapi3.NewLoader().Context); err != nil {
+		t.Fatalf("openapi.yaml is not a valid spec: %v", err)
+	}
+	doc.Servers = nil
+
+	router, err := legacyrouter.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("build spec router: %v", err)
+	}
+	return router
+}
+
+// hubOnce starts the hub's fan-out loop at most once per test binary, since
+// publishIfDefault blocks sending to timerHub.broadcast until someone is
+// running hub.run().
+var hubOnce sync.Once
+
+// newContractServer boots the real application router on a random port,
+// with manager state scoped to a scratch directory so the test never
+// touches the repo's own timers.json.
+func newContractServer(t *testing.T) string {
+	t.Helper()
+
+	hubOnce.Do(func() { go timerHub.run() })
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("chdir to scratch dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	if err := manager.load(); err != nil {
+		t.Fatalf("manager.load: %v", err)
+	}
+
+	srv := httptest.NewServer(metricsMiddleware(newRouter()))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// assertMatchesSpec replays req and resp through the kin-openapi validators
+// so drift between the handlers and openapi.yaml fails the test instead of
+// shipping silently.
+func assertMatchesSpec(t *testing.T, router routers.Router, req *http.Request, respStatus int, respHeader http.Header, respBody []byte) {
+	t.Helper()
+
+	route, pathParams, err := router.FindRoute(req)
+	if err != nil {
+		t.Fatalf("%s %s: no matching route in openapi.yaml: %v", req.Method, req.URL.Path, err)
+	}
+
+	reqInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	}
+	if err := openapi3filter.ValidateRequest(req.Context(), reqInput); err != nil {
+		t.Errorf("%s %s: request does not match openapi.yaml: %v", req.Method, req.URL.Path, err)
+	}
+
+	respInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 respStatus,
+		Header:                 respHeader,
+		Body:                   io.NopCloser(bytes.NewReader(respBody)),
+	}
+	if err := openapi3filter.ValidateResponse(req.Context(), respInput); err != nil {
+		t.Errorf("%s %s: %d response does not match openapi.yaml: %v", req.Method, req.URL.Path, respStatus, err)
+	}
+}
+
+// doAndValidate performs req against the live server and checks both the
+// request and the response it got back against the OpenAPI spec.
+func doAndValidate(t *testing.T, router routers.Router, req *http.Request) *http.Response {
+	t.Helper()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL.Path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s %s: read body: %v", req.Method, req.URL.Path, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	assertMatchesSpec(t, router, req, resp.StatusCode, resp.Header, body)
+	return resp
+}
+
+// TestContractAgainstOpenAPISpec boots the server on a random port and
+// drives the happy path for the default timer, checking every request and
+// response against openapi.yaml via kin-openapi so the handlers and the
+// published schema can't silently drift apart.
+func TestContractAgainstOpenAPISpec(t *testing.T) {
+	router := loadSpecRouter(t)
+	base := newContractServer(t)
+
+	get := func(path string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, base+path, nil)
+		if err != nil {
+			t.Fatalf("build request: %v", err)
+		}
+		return req
+	}
+	post := func(path, body string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, base+path, bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("build request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	doAndValidate(t, router, get("/api/timer"))
+	doAndValidate(t, router, post("/api/start", `{"offset_hours":0}`))
+	doAndValidate(t, router, post("/api/timer/lap", `{"label":"first"}`))
+	doAndValidate(t, router, get("/api/timer/laps"))
+	doAndValidate(t, router, get("/api/timer/laps.csv"))
+	doAndValidate(t, router, post("/api/stop", ""))
+	doAndValidate(t, router, post("/api/reset", ""))
+
+	doAndValidate(t, router, get("/api/timers"))
+	doAndValidate(t, router, post("/api/timers/work/start", `{"target_seconds":60}`))
+	doAndValidate(t, router, get("/api/timers/work"))
+	doAndValidate(t, router, post("/api/timers/work/lap", `{"label":"first"}`))
+	doAndValidate(t, router, get("/api/timers/work/laps"))
+	doAndValidate(t, router, get("/api/timers/work/laps.csv"))
+	doAndValidate(t, router, post("/api/timers/work/stop", ""))
+}
+
+// TestStreamRouteIsDocumented confirms /api/timer/stream is covered by
+// openapi.yaml (unlike the JSON routes above, its WebSocket upgrade isn't a
+// regular request/response pair kin-openapi's filters can validate, so this
+// checks route coverage via FindRoute and then drives a real upgrade to
+// prove the documented route is the one actually serving traffic).
+func TestStreamRouteIsDocumented(t *testing.T) {
+	router := loadSpecRouter(t)
+	base := newContractServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, base+"/api/timer/stream", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	if _, _, err := router.FindRoute(req); err != nil {
+		t.Fatalf("GET /api/timer/stream: no matching route in openapi.yaml: %v", err)
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(base, "http") + "/api/timer/stream"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial %s: %v", wsURL, err)
+	}
+	defer conn.Close()
+
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatalf("read snapshot from stream: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ⒸAngelaMos | 2025
+countdown.go
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	modeStopwatch = "stopwatch"
+	modeCountdown = "countdown"
+
+	webhookAttempts    = 3
+	webhookInitialWait = 1 * time.Second
+	webhookTimeout     = 5 * time.Second
+)
+
+// CompletionPayload is POSTed to a countdown timer's webhook URL once its
+// target is reached.
+type CompletionPayload struct {
+	Timer          string `json:"timer"`
+	ElapsedSeconds int64  `json:"elapsed_seconds"`
+	FinishedAt     string `json:"finished_at"`
+}
+
+// scheduleCountdownLocked arms a completion timer for ts if it's a running
+// countdown with time left. If the target has already passed (most often
+// hit when rearming on load after the process was down past the deadline),
+// the webhook fires at most once, guarded by ts.WebhookSent so repeated
+// restarts of an idle finished countdown don't re-deliver it. The caller
+// must hold manager.mu.
+func scheduleCountdownLocked(name string, ts *TimerState) {
+	if ts.Mode != modeCountdown || !ts.IsRunning {
+		return
+	}
+
+	remaining := ts.TargetSeconds - getElapsed(ts)
+	if remaining <= 0 {
+		if ts.WebhookSent {
+			return
+		}
+		ts.WebhookSent = true
+		go deliverWebhook(name, ts.WebhookURL, getElapsed(ts))
+		return
+	}
+
+	manager.countdowns[name] = time.AfterFunc(time.Duration(remaining)*time.Second, func() {
+		onCountdownFinish(name)
+	})
+}
+
+// cancelCountdownLocked stops and forgets any pending completion timer for
+// name. The caller must hold manager.mu.
+func cancelCountdownLocked(name string) {
+	if t, ok := manager.countdowns[name]; ok {
+		t.Stop()
+		delete(manager.countdowns, name)
+	}
+}
+
+// onCountdownFinish runs on the time.AfterFunc goroutine once a countdown's
+// target is reached; it re-checks the timer is still the same running,
+// not-yet-notified countdown before firing the webhook, since stop/reset
+// races against the timer having already fired.
+func onCountdownFinish(name string) {
+	manager.mu.Lock()
+	ts, ok := manager.timers[name]
+	if !ok || ts.Mode != modeCountdown || !ts.IsRunning || ts.WebhookSent {
+		manager.mu.Unlock()
+		return
+	}
+	delete(manager.countdowns, name)
+	ts.WebhookSent = true
+	webhookURL := ts.WebhookURL
+	elapsed := getElapsed(ts)
+	if err := manager.saveLocked(); err != nil {
+		slog.Error("failed to persist webhook delivery state", "timer", name, "error", err)
+	}
+	manager.mu.Unlock()
+
+	deliverWebhook(name, webhookURL, elapsed)
+}
+
+// deliverWebhook POSTs the completion payload, making up to
+// webhookAttempts attempts with exponential backoff between them before
+// giving up and logging the failure.
+func deliverWebhook(name, webhookURL string, elapsed int64) {
+	if webhookURL == "" {
+		return
+	}
+
+	payload := CompletionPayload{
+		Timer:          name,
+		ElapsedSeconds: elapsed,
+		FinishedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("failed to marshal webhook payload", "timer", name, "error", err)
+		return
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	wait := webhookInitialWait
+
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				return
+			}
+			err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+		}
+
+		if attempt == webhookAttempts {
+			slog.Error("countdown webhook delivery failed", "timer", name, "url", webhookURL, "attempt", attempt, "error", err)
+			return
+		}
+
+		slog.Warn("countdown webhook delivery failed, retrying", "timer", name, "attempt", attempt, "error", err)
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
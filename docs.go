@@ -0,0 +1,37 @@
+/*
+ⒸAngelaMos | 2025
+docs.go
+*/
+
+package main
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:embed openapi.yaml
+var openAPISpec embed.FS
+
+//go:embed static/docs.html
+var docsUIFiles embed.FS
+
+func handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	data, err := openAPISpec.ReadFile("openapi.yaml")
+	if err != nil {
+		http.Error(w, "spec not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(data)
+}
+
+func handleDocsUI(w http.ResponseWriter, r *http.Request) {
+	data, err := docsUIFiles.ReadFile("static/docs.html")
+	if err != nil {
+		http.Error(w, "docs not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(data)
+}
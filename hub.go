@@ -0,0 +1,196 @@
+/*
+ⒸAngelaMos | 2025
+hub.go
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	tickInterval  = 1 * time.Second
+	pingInterval  = 30 * time.Second
+	clientSendBuf = 8
+	writeWait     = 10 * time.Second
+	pongWait      = 60 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// client is a single WebSocket subscriber of timer updates.
+type client struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+// hub fans timer snapshots out to connected clients and owns their lifecycle.
+type hub struct {
+	register   chan *client
+	unregister chan *client
+	broadcast  chan []byte
+	clients    map[*client]bool
+}
+
+func newHub() *hub {
+	return &hub{
+		register:   make(chan *client),
+		unregister: make(chan *client),
+		broadcast:  make(chan []byte),
+		clients:    make(map[*client]bool),
+	}
+}
+
+// run owns h.clients and must execute in its own goroutine for the life of
+// the process; it fans out both the once-a-second tick and state-transition
+// events published via publish.
+func (h *hub) run() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case c := <-h.register:
+			h.clients[c] = true
+
+		case c := <-h.unregister:
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				close(c.send)
+			}
+
+		case msg := <-h.broadcast:
+			h.fanOut(msg)
+
+		case <-ticker.C:
+			h.fanOut(currentSnapshot())
+		}
+	}
+}
+
+func (h *hub) fanOut(msg []byte) {
+	for c := range h.clients {
+		select {
+		case c.send <- msg:
+		default:
+			// client is too slow to keep up; drop it rather than block the hub.
+			delete(h.clients, c)
+			close(c.send)
+		}
+	}
+}
+
+// publish pushes a pre-encoded snapshot to every connected client
+// immediately, in addition to the once-a-second tick. The stream only ever
+// carries the default timer, so callers must not hold manager.mu when
+// calling publish, since the hub's own tick also needs to acquire it.
+func (h *hub) publish(snapshot []byte) {
+	h.broadcast <- snapshot
+}
+
+// publishIfDefault pushes resp to the hub only when it describes the
+// default timer, keeping /api/timer/stream scoped to the legacy single-timer
+// stream rather than fanning out every named timer's transitions.
+func publishIfDefault(name string, resp TimerResponse) {
+	if name != defaultTimerName {
+		return
+	}
+	timerHub.publish(encodeSnapshot(resp))
+}
+
+func encodeSnapshot(resp TimerResponse) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		slog.Error("failed to marshal timer snapshot", "error", err)
+		return []byte("{}")
+	}
+	return data
+}
+
+// currentSnapshot encodes the default timer's current state, used for the
+// once-a-second tick.
+func currentSnapshot() []byte {
+	manager.mu.RLock()
+	ts, ok := manager.timers[defaultTimerName]
+	if !ok {
+		manager.mu.RUnlock()
+		return []byte("{}")
+	}
+	resp := timerResponseLocked(defaultTimerName, ts)
+	manager.mu.RUnlock()
+
+	return encodeSnapshot(resp)
+}
+
+func (h *hub) handleStream(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("websocket upgrade failed", "error", err)
+		return
+	}
+
+	c := &client{conn: conn, send: make(chan []byte, clientSendBuf)}
+	h.register <- c
+
+	go c.writePump()
+	go c.readPump(h)
+}
+
+// readPump drains the connection so close/control frames are observed; the
+// stream is one-way, so any client message is discarded.
+func (c *client) readPump(h *hub) {
+	defer func() {
+		h.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ⒸAngelaMos | 2025
+laps.go
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Lap is one recorded split of a timer: the elapsed time at the moment it
+// was taken, how much time passed since the previous lap, and an optional
+// caller-supplied label.
+type Lap struct {
+	ID      int    `json:"id"`
+	At      int64  `json:"at"`
+	Elapsed int64  `json:"elapsed_seconds"`
+	Delta   int64  `json:"delta_seconds"`
+	Label   string `json:"label"`
+}
+
+type LapRequest struct {
+	Label string `json:"label"`
+}
+
+// handleLap serves POST /api/timers/{name}/lap and its POST /api/timer/lap
+// alias, recording the timer's current elapsed time as a new lap.
+func handleLap(w http.ResponseWriter, r *http.Request) {
+	name := timerNameOrDefault(r)
+
+	var req LapRequest
+	if !decodeAndValidate(w, r, "Lap", &req) {
+		return
+	}
+
+	manager.mu.Lock()
+
+	ts, ok := manager.timers[name]
+	if !ok {
+		manager.mu.Unlock()
+		writeNotFound(w, "Lap", name)
+		return
+	}
+
+	elapsed := getElapsed(ts)
+	var prevElapsed int64
+	if n := len(ts.Laps); n > 0 {
+		prevElapsed = ts.Laps[n-1].Elapsed
+	}
+
+	lap := Lap{
+		ID:      len(ts.Laps) + 1,
+		At:      time.Now().Unix(),
+		Elapsed: elapsed,
+		Delta:   elapsed - prevElapsed,
+		Label:   req.Label,
+	}
+	ts.Laps = append(ts.Laps, lap)
+
+	if err := manager.saveLocked(); err != nil {
+		ts.Laps = ts.Laps[:len(ts.Laps)-1]
+		manager.mu.Unlock()
+		slog.Error("failed to save timers", "error", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Lap", "failed to save state")
+		return
+	}
+
+	manager.mu.Unlock()
+
+	slog.Info("lap recorded", "timer", name, "elapsed_seconds", elapsed, "label", req.Label)
+	writeJSON(w, lap)
+}
+
+// handleLaps serves GET /api/timers/{name}/laps and its GET /api/timer/laps
+// alias.
+func handleLaps(w http.ResponseWriter, r *http.Request) {
+	name := timerNameOrDefault(r)
+
+	manager.mu.RLock()
+	ts, ok := manager.timers[name]
+	var laps []Lap
+	if ok {
+		laps = append(laps, ts.Laps...)
+	}
+	manager.mu.RUnlock()
+
+	if !ok {
+		writeNotFound(w, "Laps", name)
+		return
+	}
+	if laps == nil {
+		laps = []Lap{}
+	}
+	writeJSON(w, laps)
+}
+
+// handleLapsCSV serves GET /api/timers/{name}/laps.csv and its
+// GET /api/timer/laps.csv alias, streaming the same history as a CSV for
+// billing/time-tracking exports.
+func handleLapsCSV(w http.ResponseWriter, r *http.Request) {
+	name := timerNameOrDefault(r)
+
+	manager.mu.RLock()
+	ts, ok := manager.timers[name]
+	var laps []Lap
+	if ok {
+		laps = append(laps, ts.Laps...)
+	}
+	manager.mu.RUnlock()
+
+	if !ok {
+		writeNotFound(w, "LapsCSV", name)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`-laps.csv"`)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "at", "elapsed_seconds", "delta_seconds", "label"})
+	for _, lap := range laps {
+		_ = cw.Write([]string{
+			strconv.Itoa(lap.ID),
+			strconv.FormatInt(lap.At, 10),
+			strconv.FormatInt(lap.Elapsed, 10),
+			strconv.FormatInt(lap.Delta, 10),
+			lap.Label,
+		})
+	}
+	cw.Flush()
+}
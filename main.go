@@ -8,10 +8,10 @@ package main
 import (
 	"embed"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
-	"sync"
 	"time"
 )
 
@@ -19,63 +19,56 @@ import (
 var staticFiles embed.FS
 
 type TimerState struct {
-	StartTime     int64 `json:"start_time"`
-	StoppedAt     int64 `json:"stopped_at"`
-	OffsetSeconds int64 `json:"offset_seconds"`
-	IsRunning     bool  `json:"is_running"`
+	StartTime     int64  `json:"start_time"`
+	StoppedAt     int64  `json:"stopped_at"`
+	OffsetSeconds int64  `json:"offset_seconds"`
+	IsRunning     bool   `json:"is_running"`
+	Laps          []Lap  `json:"laps,omitempty"`
+	Mode          string `json:"mode"`
+	TargetSeconds int64  `json:"target_seconds,omitempty"`
+	WebhookURL    string `json:"webhook_url,omitempty"`
+
+	// WebhookSent marks that the countdown's completion webhook has already
+	// been delivered (or at least attempted), so rearming on load doesn't
+	// re-notify a countdown that finished while the process was down and
+	// has just been sitting idle ever since.
+	WebhookSent bool `json:"webhook_sent,omitempty"`
 }
 
 type TimerResponse struct {
+	Name             string `json:"name"`
 	IsRunning        bool   `json:"is_running"`
 	ElapsedSeconds   int64  `json:"elapsed_seconds"`
 	ElapsedFormatted string `json:"elapsed_formatted"`
+	Mode             string `json:"mode"`
+	RemainingSeconds int64  `json:"remaining_seconds,omitempty"`
+	Finished         bool   `json:"finished,omitempty"`
 }
 
 type StartRequest struct {
-	OffsetHours float64 `json:"offset_hours"`
+	OffsetHours   float64    `json:"offset_hours" validate:"gte=0,lte=8760"`
+	TargetSeconds int64      `json:"target_seconds,omitempty" validate:"gte=0"`
+	TargetAt      *time.Time `json:"target_at,omitempty"`
+	WebhookURL    string     `json:"webhook_url,omitempty" validate:"omitempty,url"`
 }
 
 var (
-	state     TimerState
-	stateMu   sync.RWMutex
-	stateFile = "timer.json"
+	manager  = newTimerManager()
+	timerHub = newHub()
 )
 
-func loadState() error {
-	stateMu.Lock()
-	defer stateMu.Unlock()
-
-	data, err := os.ReadFile(stateFile)
-	if os.IsNotExist(err) {
-		state = TimerState{}
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(data, &state)
-}
-
-func saveState() error {
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(stateFile, data, 0644)
-}
-
-func getElapsed() int64 {
-	if !state.IsRunning && state.StartTime == 0 {
-		return state.OffsetSeconds
+func getElapsed(ts *TimerState) int64 {
+	if !ts.IsRunning && ts.StartTime == 0 {
+		return ts.OffsetSeconds
 	}
 
 	var elapsed int64
-	if state.IsRunning {
-		elapsed = time.Now().Unix() - state.StartTime
+	if ts.IsRunning {
+		elapsed = time.Now().Unix() - ts.StartTime
 	} else {
-		elapsed = state.StoppedAt - state.StartTime
+		elapsed = ts.StoppedAt - ts.StartTime
 	}
-	return elapsed + state.OffsetSeconds
+	return elapsed + ts.OffsetSeconds
 }
 
 func formatElapsed(seconds int64) string {
@@ -103,105 +96,224 @@ func formatElapsed(seconds int64) string {
 	return result
 }
 
-func handleGetTimer(w http.ResponseWriter, r *http.Request) {
-	stateMu.RLock()
-	elapsed := getElapsed()
-	running := state.IsRunning
-	stateMu.RUnlock()
+func timerResponseLocked(name string, ts *TimerState) TimerResponse {
+	elapsed := getElapsed(ts)
+	mode := ts.Mode
+	if mode == "" {
+		mode = modeStopwatch
+	}
 
 	resp := TimerResponse{
-		IsRunning:        running,
+		Name:             name,
+		IsRunning:        ts.IsRunning,
 		ElapsedSeconds:   elapsed,
 		ElapsedFormatted: formatElapsed(elapsed),
+		Mode:             mode,
+	}
+
+	if mode == modeCountdown {
+		remaining := ts.TargetSeconds - elapsed
+		if remaining < 0 {
+			remaining = 0
+		}
+		resp.RemainingSeconds = remaining
+		resp.Finished = elapsed >= ts.TargetSeconds
 	}
 
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(v)
 }
 
+// handleGetTimer serves GET /api/timers/{name} and its GET /api/timer alias
+// (name defaulting to defaultTimerName).
+func handleGetTimer(w http.ResponseWriter, r *http.Request) {
+	name := timerNameOrDefault(r)
+
+	ts, ok := manager.get(name)
+	if !ok {
+		writeNotFound(w, "Get", name)
+		return
+	}
+
+	manager.mu.RLock()
+	resp := timerResponseLocked(name, ts)
+	manager.mu.RUnlock()
+
+	writeJSON(w, resp)
+}
+
+// handleListTimers serves GET /api/timers, returning a snapshot of every
+// known timer.
+func handleListTimers(w http.ResponseWriter, r *http.Request) {
+	names := manager.names()
+
+	manager.mu.RLock()
+	resp := make([]TimerResponse, 0, len(names))
+	for _, name := range names {
+		if ts, ok := manager.timers[name]; ok {
+			resp = append(resp, timerResponseLocked(name, ts))
+		}
+	}
+	manager.mu.RUnlock()
+
+	writeJSON(w, resp)
+}
+
+// handleStart serves POST /api/timers/{name}/start and its POST /api/start
+// alias, creating the named timer if it doesn't exist yet. getOrCreate
+// rejects names that fail validateTimerName or would exceed maxTimers
+// before a new entry is ever created.
 func handleStart(w http.ResponseWriter, r *http.Request) {
+	name := timerNameOrDefault(r)
+
 	var req StartRequest
-	if r.ContentLength > 0 {
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "invalid json", http.StatusBadRequest)
-			return
+	if !decodeAndValidate(w, r, "Start", &req) {
+		return
+	}
+
+	ts, err := manager.getOrCreate(name)
+	if err != nil {
+		code := ErrCodeOutOfRange
+		if errors.Is(err, errTooManyTimers) {
+			code = ErrCodeLimitExceeded
 		}
+		writeError(w, http.StatusBadRequest, code, "Start", err.Error())
+		return
 	}
 
-	stateMu.Lock()
-	defer stateMu.Unlock()
+	manager.mu.Lock()
 
-	if state.IsRunning {
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(map[string]string{"status": "already running"})
+	if ts.IsRunning {
+		manager.mu.Unlock()
+		writeConflict(w, "Start", "timer already running: "+name)
 		return
 	}
 
 	now := time.Now().Unix()
 
-	if state.StoppedAt > 0 {
-		pausedDuration := state.StoppedAt - state.StartTime
-		state.OffsetSeconds += pausedDuration
-		state.StoppedAt = 0
+	if ts.StoppedAt > 0 {
+		pausedDuration := ts.StoppedAt - ts.StartTime
+		ts.OffsetSeconds += pausedDuration
+		ts.StoppedAt = 0
 	}
 
 	if req.OffsetHours > 0 {
-		state.OffsetSeconds = int64(req.OffsetHours * 3600)
+		ts.OffsetSeconds = int64(req.OffsetHours * 3600)
 	}
 
-	state.StartTime = now
-	state.IsRunning = true
+	switch {
+	case req.TargetAt != nil:
+		ts.Mode = modeCountdown
+		ts.TargetSeconds = req.TargetAt.Unix() - now
+	case req.TargetSeconds > 0:
+		ts.Mode = modeCountdown
+		ts.TargetSeconds = req.TargetSeconds
+	default:
+		ts.Mode = modeStopwatch
+		ts.TargetSeconds = 0
+	}
+	ts.WebhookURL = req.WebhookURL
+	ts.WebhookSent = false
 
-	if err := saveState(); err != nil {
-		slog.Error("failed to save state", "error", err)
-		http.Error(w, "failed to save state", http.StatusInternalServerError)
+	ts.StartTime = now
+	ts.IsRunning = true
+
+	if err := manager.saveLocked(); err != nil {
+		manager.mu.Unlock()
+		slog.Error("failed to save timers", "error", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Start", "failed to save state")
 		return
 	}
 
-	slog.Info("timer started", "offset_hours", req.OffsetHours)
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"status": "started"})
+	cancelCountdownLocked(name)
+	scheduleCountdownLocked(name, ts)
+
+	resp := timerResponseLocked(name, ts)
+	manager.mu.Unlock()
+
+	slog.Info("timer started", "timer", name, "offset_hours", req.OffsetHours, "mode", ts.Mode)
+	startsTotal.WithLabelValues(name).Inc()
+	publishIfDefault(name, resp)
+	writeJSON(w, map[string]string{"status": "started"})
 }
 
+// handleStop serves POST /api/timers/{name}/stop and its POST /api/stop
+// alias.
 func handleStop(w http.ResponseWriter, r *http.Request) {
-	stateMu.Lock()
-	defer stateMu.Unlock()
+	name := timerNameOrDefault(r)
 
-	if !state.IsRunning {
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(map[string]string{"status": "already stopped"})
+	manager.mu.Lock()
+
+	ts, ok := manager.timers[name]
+	if !ok {
+		manager.mu.Unlock()
+		writeNotFound(w, "Stop", name)
+		return
+	}
+
+	if !ts.IsRunning {
+		manager.mu.Unlock()
+		writeConflict(w, "Stop", "timer already stopped: "+name)
 		return
 	}
 
-	state.StoppedAt = time.Now().Unix()
-	state.IsRunning = false
+	ts.StoppedAt = time.Now().Unix()
+	ts.IsRunning = false
 
-	if err := saveState(); err != nil {
-		slog.Error("failed to save state", "error", err)
-		http.Error(w, "failed to save state", http.StatusInternalServerError)
+	if err := manager.saveLocked(); err != nil {
+		manager.mu.Unlock()
+		slog.Error("failed to save timers", "error", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Stop", "failed to save state")
 		return
 	}
 
-	slog.Info("timer stopped")
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"status": "stopped"})
+	cancelCountdownLocked(name)
+
+	resp := timerResponseLocked(name, ts)
+	manager.mu.Unlock()
+
+	slog.Info("timer stopped", "timer", name)
+	stopsTotal.WithLabelValues(name).Inc()
+	publishIfDefault(name, resp)
+	writeJSON(w, map[string]string{"status": "stopped"})
 }
 
+// handleReset serves POST /api/timers/{name}/reset and its POST /api/reset
+// alias.
 func handleReset(w http.ResponseWriter, r *http.Request) {
-	stateMu.Lock()
-	defer stateMu.Unlock()
+	name := timerNameOrDefault(r)
 
-	state = TimerState{}
+	manager.mu.Lock()
 
-	if err := saveState(); err != nil {
-		slog.Error("failed to save state", "error", err)
-		http.Error(w, "failed to save state", http.StatusInternalServerError)
+	ts, ok := manager.timers[name]
+	if !ok {
+		manager.mu.Unlock()
+		writeNotFound(w, "Reset", name)
 		return
 	}
 
-	slog.Info("timer reset")
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"status": "reset"})
+	*ts = TimerState{}
+
+	if err := manager.saveLocked(); err != nil {
+		manager.mu.Unlock()
+		slog.Error("failed to save timers", "error", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "Reset", "failed to save state")
+		return
+	}
+
+	cancelCountdownLocked(name)
+
+	resp := timerResponseLocked(name, ts)
+	manager.mu.Unlock()
+
+	slog.Info("timer reset", "timer", name)
+	resetsTotal.WithLabelValues(name).Inc()
+	publishIfDefault(name, resp)
+	writeJSON(w, map[string]string{"status": "reset"})
 }
 
 func handleIndex(w http.ResponseWriter, r *http.Request) {
@@ -214,24 +326,63 @@ func handleIndex(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(data)
 }
 
-func main() {
-	if err := loadState(); err != nil {
-		slog.Error("failed to load state", "error", err)
-		os.Exit(1)
+// timerNameOrDefault reads the {name} path value used by the /api/timers/...
+// routes, falling back to defaultTimerName for the legacy /api/timer...
+// aliases where no such path segment exists.
+func timerNameOrDefault(r *http.Request) string {
+	if name := r.PathValue("name"); name != "" {
+		return name
 	}
+	return defaultTimerName
+}
 
+// newRouter builds the full set of routes served by the application. It is
+// split out of main so tests can mount the same handler on an httptest
+// server without going through ListenAndServe.
+func newRouter() *http.ServeMux {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /{$}", handleIndex)
+	mux.HandleFunc("GET /openapi.yaml", handleOpenAPISpec)
+	mux.HandleFunc("GET /docs", handleDocsUI)
+
+	// Legacy single-timer routes, aliased to the "default" timer.
 	mux.HandleFunc("GET /api/timer", handleGetTimer)
+	mux.HandleFunc("GET /api/timer/stream", timerHub.handleStream)
 	mux.HandleFunc("POST /api/start", handleStart)
 	mux.HandleFunc("POST /api/stop", handleStop)
 	mux.HandleFunc("POST /api/reset", handleReset)
+	mux.HandleFunc("POST /api/timer/lap", handleLap)
+	mux.HandleFunc("GET /api/timer/laps", handleLaps)
+	mux.HandleFunc("GET /api/timer/laps.csv", handleLapsCSV)
+
+	// Named-timer routes.
+	mux.HandleFunc("GET /api/timers", handleListTimers)
+	mux.HandleFunc("GET /api/timers/{name}", handleGetTimer)
+	mux.HandleFunc("POST /api/timers/{name}/start", handleStart)
+	mux.HandleFunc("POST /api/timers/{name}/stop", handleStop)
+	mux.HandleFunc("POST /api/timers/{name}/reset", handleReset)
+	mux.HandleFunc("POST /api/timers/{name}/lap", handleLap)
+	mux.HandleFunc("GET /api/timers/{name}/laps", handleLaps)
+	mux.HandleFunc("GET /api/timers/{name}/laps.csv", handleLapsCSV)
+
+	mux.Handle("GET /metrics", metricsHandler)
+
+	return mux
+}
+
+func main() {
+	if err := manager.load(); err != nil {
+		slog.Error("failed to load timers", "error", err)
+		os.Exit(1)
+	}
+
+	go timerHub.run()
 
 	addr := ":8329"
 	slog.Info("server starting", "addr", "http://localhost"+addr)
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
+	if err := http.ListenAndServe(addr, metricsMiddleware(newRouter())); err != nil {
 		slog.Error("server failed", "error", err)
 		os.Exit(1)
 	}
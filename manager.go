@@ -0,0 +1,152 @@
+/*
+ⒸAngelaMos | 2025
+manager.go
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimerName = "default"
+	timersFile       = "timers.json"
+
+	// maxTimers caps how many distinct named timers a single process will
+	// track, so an unauthenticated caller can't mint unbounded entries (and
+	// unbounded Prometheus label values, see metrics.go) by POSTing to
+	// /api/timers/{name}/start with a new name every time.
+	maxTimers = 1000
+)
+
+// errTooManyTimers is returned by getOrCreate once maxTimers distinct
+// timers already exist.
+var errTooManyTimers = errors.New("maximum number of timers reached")
+
+// TimerManager owns the full collection of named timers and is the single
+// point of synchronization for reading and mutating any of them, mirroring
+// the coarse-grained locking the single-timer version used.
+type TimerManager struct {
+	mu     sync.RWMutex
+	timers map[string]*TimerState
+
+	// countdowns holds the pending completion timer for each running
+	// countdown, keyed by timer name. It is transient process state, never
+	// persisted, and guarded by mu like everything else here.
+	countdowns map[string]*time.Timer
+}
+
+func newTimerManager() *TimerManager {
+	return &TimerManager{
+		timers:     make(map[string]*TimerState),
+		countdowns: make(map[string]*time.Timer),
+	}
+}
+
+// load reads timersFile into the manager, creating the default timer if it
+// is absent so the legacy /api/timer aliases always have something to serve.
+func (m *TimerManager) load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := os.ReadFile(timersFile)
+	if os.IsNotExist(err) {
+		m.timers = make(map[string]*TimerState)
+	} else if err != nil {
+		return err
+	} else if err := json.Unmarshal(data, &m.timers); err != nil {
+		return err
+	}
+
+	if _, ok := m.timers[defaultTimerName]; !ok {
+		m.timers[defaultTimerName] = &TimerState{}
+	}
+
+	for name, ts := range m.timers {
+		scheduleCountdownLocked(name, ts)
+	}
+
+	// scheduleCountdownLocked may have just set WebhookSent on an
+	// already-finished countdown whose webhook it fired during this load;
+	// persist that so the next restart doesn't fire it again.
+	return m.saveLocked()
+}
+
+// saveLocked persists every timer to a temp file and renames it over
+// timersFile so a crash mid-write can never leave a torn file behind. The
+// caller must already hold m.mu.
+func (m *TimerManager) saveLocked() error {
+	data, err := json.MarshalIndent(m.timers, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := timersFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Clean(timersFile))
+}
+
+// get returns the named timer, or nil and false if it doesn't exist.
+func (m *TimerManager) get(name string) (*TimerState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ts, ok := m.timers[name]
+	return ts, ok
+}
+
+// getOrCreate returns the named timer, creating and persisting an empty one
+// if it doesn't exist yet. Creating a new timer validates name (length and
+// charset) and enforces maxTimers, since name comes straight from the URL
+// path and is otherwise unbounded attacker input.
+func (m *TimerManager) getOrCreate(name string) (*TimerState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ts, ok := m.timers[name]
+	if ok {
+		return ts, nil
+	}
+
+	if err := validateTimerName(name); err != nil {
+		return nil, err
+	}
+	if len(m.timers) >= maxTimers {
+		return nil, errTooManyTimers
+	}
+
+	ts = &TimerState{}
+	m.timers[name] = ts
+	if err := m.saveLocked(); err != nil {
+		delete(m.timers, name)
+		return nil, fmt.Errorf("create timer %q: %w", name, err)
+	}
+	return ts, nil
+}
+
+// names returns every known timer name, sorted for stable listings.
+func (m *TimerManager) names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedKeys(m.timers)
+}
+
+// sortedKeys returns the map's keys in sorted order. The caller is
+// responsible for holding whatever lock guards timers.
+func sortedKeys(timers map[string]*TimerState) []string {
+	names := make([]string, 0, len(timers))
+	for name := range timers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,130 @@
+/*
+ⒸAngelaMos | 2025
+metrics.go
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	elapsedDesc = prometheus.NewDesc(
+		"chronometer_elapsed_seconds",
+		"Current elapsed seconds for a timer.",
+		[]string{"timer"}, nil,
+	)
+	runningDesc = prometheus.NewDesc(
+		"chronometer_running",
+		"Whether a timer is currently running (1) or stopped (0).",
+		[]string{"timer"}, nil,
+	)
+
+	startsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "chronometer_starts_total",
+		Help: "Total number of times a timer has been started.",
+	}, []string{"timer"})
+
+	stopsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "chronometer_stops_total",
+		Help: "Total number of times a timer has been stopped.",
+	}, []string{"timer"})
+
+	resetsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "chronometer_resets_total",
+		Help: "Total number of times a timer has been reset.",
+	}, []string{"timer"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chronometer_http_request_duration_seconds",
+		Help:    "HTTP handler latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path", "status"})
+)
+
+func init() {
+	prometheus.MustRegister(&timerCollector{}, startsTotal, stopsTotal, resetsTotal, httpDuration)
+}
+
+// timerCollector reads manager state under an RLock at scrape time, so the
+// exposed gauges are always fresh without a background goroutine keeping
+// them in sync. Every name it labels a series with, and every name used in
+// startsTotal/stopsTotal/resetsTotal above, is either defaultTimerName or
+// came through TimerManager.getOrCreate, which bounds both the charset/length of each
+// name (validateTimerName) and the total number of distinct timers
+// (maxTimers) — without that bound, an attacker-controlled timer name would
+// cause unbounded label cardinality the same way raw request paths once did
+// for httpDuration.
+type timerCollector struct{}
+
+func (c *timerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- elapsedDesc
+	ch <- runningDesc
+}
+
+func (c *timerCollector) Collect(ch chan<- prometheus.Metric) {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+
+	for name, ts := range manager.timers {
+		running := 0.0
+		if ts.IsRunning {
+			running = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(elapsedDesc, prometheus.GaugeValue, float64(getElapsed(ts)), name)
+		ch <- prometheus.MustNewConstMetric(runningDesc, prometheus.GaugeValue, running, name)
+	}
+}
+
+// metricsMiddleware times every request and records it against
+// httpDuration, labeled by the matched route pattern rather than the raw
+// path so named-timer routes (e.g. /api/timers/{name}/start) don't create
+// a new series per timer slug.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		pattern := r.Pattern
+		if pattern == "" {
+			pattern = "unmatched"
+		}
+		httpDuration.WithLabelValues(r.Method, pattern, strconv.Itoa(rec.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code written
+// by the inner handler. It forwards Hijack so the WebSocket upgrade in
+// hub.go (which requires the ResponseWriter to implement http.Hijacker)
+// keeps working when every route is wrapped in metricsMiddleware.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not implement http.Hijacker")
+	}
+	return hijacker.Hijack()
+}
+
+var metricsHandler = promhttp.Handler()
@@ -0,0 +1,100 @@
+/*
+ⒸAngelaMos | 2025
+validation.go
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Numeric error codes returned in ErrorEnvelope.ErrorCode, so clients can
+// switch on a stable code instead of parsing the message prose.
+const (
+	ErrCodeInvalidJSON   = 1
+	ErrCodeMissingField  = 2
+	ErrCodeOutOfRange    = 3
+	ErrCodeConflict      = 4
+	ErrCodeNotFound      = 5
+	ErrCodeInternal      = 6
+	ErrCodeLimitExceeded = 7
+)
+
+const maxTimerNameLen = 64
+
+// timerNamePattern restricts timer names to a safe, predictable charset so
+// an attacker-controlled {name} path segment can never produce something
+// unsafe to use as a JSON map key, a log field, or a Prometheus label value.
+var timerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// validateTimerName reports whether name is acceptable for a new named
+// timer.
+func validateTimerName(name string) error {
+	if name == "" || len(name) > maxTimerNameLen {
+		return fmt.Errorf("timer name must be 1-%d characters", maxTimerNameLen)
+	}
+	if !timerNamePattern.MatchString(name) {
+		return errors.New("timer name may only contain letters, digits, '_', and '-'")
+	}
+	return nil
+}
+
+// ErrorEnvelope is the uniform JSON error shape returned by every handler.
+type ErrorEnvelope struct {
+	ErrorCode int    `json:"error_code"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+}
+
+var validate = validator.New()
+
+func writeError(w http.ResponseWriter, status, code int, op, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorEnvelope{ErrorCode: code, Error: op, Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter, op, name string) {
+	writeError(w, http.StatusNotFound, ErrCodeNotFound, op, "timer not found: "+name)
+}
+
+func writeConflict(w http.ResponseWriter, op, message string) {
+	writeError(w, http.StatusConflict, ErrCodeConflict, op, message)
+}
+
+// decodeAndValidate decodes the JSON request body (if any) into req and
+// runs struct validation, writing a structured ErrorEnvelope and returning
+// false on the first failure of either step.
+func decodeAndValidate(w http.ResponseWriter, r *http.Request, op string, req any) bool {
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidJSON, op, "invalid JSON: "+err.Error())
+			return false
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var verrs validator.ValidationErrors
+		if errors.As(err, &verrs) && len(verrs) > 0 {
+			fe := verrs[0]
+			code := ErrCodeOutOfRange
+			if fe.Tag() == "required" {
+				code = ErrCodeMissingField
+			}
+			writeError(w, http.StatusBadRequest, code, op,
+				fmt.Sprintf("field %q failed validation %q", fe.Field(), fe.Tag()))
+			return false
+		}
+		writeError(w, http.StatusBadRequest, ErrCodeOutOfRange, op, err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,161 @@
+/*
+ⒸAngelaMos | 2025
+validation_test.go
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// doJSON issues method/path against base with an optional JSON body and
+// decodes the response as an ErrorEnvelope, since every handler under test
+// here is expected to fail.
+func doJSON(t *testing.T, base, method, path, body string) (*http.Response, ErrorEnvelope) {
+	t.Helper()
+
+	var reqBody *strings.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	} else {
+		reqBody = strings.NewReader("")
+	}
+
+	req, err := http.NewRequest(method, base+path, reqBody)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	var env ErrorEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		t.Fatalf("%s %s: decode error envelope: %v", method, path, err)
+	}
+	return resp, env
+}
+
+// TestValidationErrors drives the requests this layer exists to reject and
+// checks both the HTTP status and the ErrorEnvelope's error_code, so a
+// regression in decodeAndValidate/validateTimerName shows up as a precise
+// failure instead of a generic "didn't 200" check.
+func TestValidationErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   int
+	}{
+		{
+			name:       "malformed JSON body",
+			method:     http.MethodPost,
+			path:       "/api/start",
+			body:       `{"offset_hours":`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidJSON,
+		},
+		{
+			name:       "offset_hours out of range",
+			method:     http.MethodPost,
+			path:       "/api/start",
+			body:       `{"offset_hours":-1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeOutOfRange,
+		},
+		{
+			name:       "webhook_url not a URL",
+			method:     http.MethodPost,
+			path:       "/api/start",
+			body:       `{"webhook_url":"not-a-url"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeOutOfRange,
+		},
+		{
+			name:       "timer name bad charset",
+			method:     http.MethodPost,
+			path:       "/api/timers/bad name!/start",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeOutOfRange,
+		},
+		{
+			name:       "timer name too long",
+			method:     http.MethodPost,
+			path:       "/api/timers/" + strings.Repeat("a", maxTimerNameLen+1) + "/start",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeOutOfRange,
+		},
+		{
+			name:       "get unknown timer",
+			method:     http.MethodGet,
+			path:       "/api/timers/does-not-exist",
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrCodeNotFound,
+		},
+		{
+			name:       "stop unknown timer",
+			method:     http.MethodPost,
+			path:       "/api/timers/does-not-exist/stop",
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrCodeNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			base := newContractServer(t)
+
+			resp, env := doJSON(t, base, tc.method, tc.path, tc.body)
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if env.ErrorCode != tc.wantCode {
+				t.Errorf("error_code = %d, want %d", env.ErrorCode, tc.wantCode)
+			}
+		})
+	}
+}
+
+// TestConflictErrors covers the state-dependent conflicts that a single
+// request can't trigger on its own: starting an already-running timer and
+// stopping an already-stopped one.
+func TestConflictErrors(t *testing.T) {
+	t.Run("start already running", func(t *testing.T) {
+		base := newContractServer(t)
+		doJSON(t, base, http.MethodPost, "/api/start", `{}`)
+
+		resp, env := doJSON(t, base, http.MethodPost, "/api/start", `{}`)
+		if resp.StatusCode != http.StatusConflict {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusConflict)
+		}
+		if env.ErrorCode != ErrCodeConflict {
+			t.Errorf("error_code = %d, want %d", env.ErrorCode, ErrCodeConflict)
+		}
+	})
+
+	t.Run("stop already stopped", func(t *testing.T) {
+		base := newContractServer(t)
+
+		resp, env := doJSON(t, base, http.MethodPost, "/api/stop", "")
+		if resp.StatusCode != http.StatusConflict {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusConflict)
+		}
+		if env.ErrorCode != ErrCodeConflict {
+			t.Errorf("error_code = %d, want %d", env.ErrorCode, ErrCodeConflict)
+		}
+	})
+}